@@ -0,0 +1,73 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maps provides a client for the Google Maps Roads API.
+package maps
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	roadspb "github.com/julianshen/google-maps-services-go/roads/v1op"
+)
+
+// Client is a client for the Google Maps Roads API.
+type Client struct {
+	httpClient          *http.Client
+	baseURL             string
+	apiKey              string
+	roadsClient         roadspb.RoadsServiceClient
+	responseInterceptor func(req *http.Request, body []byte)
+	decoder             func(io.Reader, interface{}) error
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client) error
+
+// NewClient constructs a Client using the given options. An API key set
+// via WithAPIKey is required.
+func NewClient(options ...ClientOption) (*Client, error) {
+	c := &Client{httpClient: &http.Client{}}
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.apiKey == "" {
+		return nil, errors.New("maps: API Key missing")
+	}
+	return c, nil
+}
+
+// WithAPIKey returns a ClientOption that sets the Client's API key.
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Client) error {
+		c.apiKey = apiKey
+		return nil
+	}
+}
+
+// generateAuthQuery adds the API key to q and returns the encoded query
+// string.
+func (c *Client) generateAuthQuery(path string, q url.Values, accepts ...bool) (string, error) {
+	q.Set("key", c.apiKey)
+	return q.Encode(), nil
+}
+
+// httpDo executes req using the Client's configured http.Client.
+func (c *Client) httpDo(req *http.Request) (*http.Response, error) {
+	return c.httpClient.Do(req)
+}
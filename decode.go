@@ -0,0 +1,98 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// APIError is returned when a Google Maps API response carries the
+// standard `{"error": {"code": ..., "message": ..., "status": ...}}`
+// error envelope, so that callers can distinguish failure modes such as
+// RESOURCE_EXHAUSTED (quota exceeded) from INVALID_ARGUMENT without
+// string-matching the message.
+type APIError struct {
+	// Code is the HTTP-style status code reported in the error envelope.
+	Code int `json:"code"`
+
+	// Message is a human-readable description of the error.
+	Message string `json:"message"`
+
+	// Status is the canonical error code, e.g. "RESOURCE_EXHAUSTED" or
+	// "INVALID_ARGUMENT".
+	Status string `json:"status"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("maps: API error %s (%d): %s", e.Status, e.Code, e.Message)
+}
+
+type apiErrorEnvelope struct {
+	Error *APIError `json:"error"`
+}
+
+// decode reads resp.Body, reports it to the Client's response interceptor
+// if one is configured, and decodes it into out. If the body carries a
+// Google API error envelope, it is returned as an *APIError instead of
+// being passed to the decoder.
+func (c *Client) decode(req *http.Request, resp *http.Response, out interface{}) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if c.responseInterceptor != nil {
+		c.responseInterceptor(req, body)
+	}
+
+	var envelope apiErrorEnvelope
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error != nil {
+		return envelope.Error
+	}
+
+	if c.decoder != nil {
+		return c.decoder(bytes.NewReader(body), out)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// WithResponseInterceptor returns a ClientOption that invokes fn with the
+// request and the raw, undecoded response body for every Roads API call
+// made over REST; calls routed through WithGRPC are not reported. Useful for logging, replay/fixture capture, or debugging API error
+// envelopes.
+func WithResponseInterceptor(fn func(req *http.Request, body []byte)) ClientOption {
+	return func(c *Client) error {
+		c.responseInterceptor = fn
+		return nil
+	}
+}
+
+// WithDecoder returns a ClientOption that replaces the default
+// encoding/json decoding of response bodies with fn, e.g. to use a
+// drop-in faster decoder such as jsoniter. Note the body is always read
+// into memory first (to support the API error envelope check and
+// WithResponseInterceptor), so fn is not handed a live network stream
+// and gains no incremental-decoding benefit for large interpolated paths.
+func WithDecoder(fn func(io.Reader, interface{}) error) ClientOption {
+	return func(c *Client) error {
+		c.decoder = fn
+		return nil
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestDecode_APIErrorEnvelope(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"code":403,"message":"You have exceeded your rate-limit for this api.","status":"RESOURCE_EXHAUSTED"}}`)
+	}))
+	defer ts.Close()
+
+	c := &Client{httpClient: ts.Client(), baseURL: ts.URL}
+
+	_, err := c.NearestRoads(context.Background(), &NearestRoadsRequest{
+		Points: []LatLng{{Lat: 1, Lng: 2}},
+	})
+	if err == nil {
+		t.Fatal("got nil error, want an *APIError")
+	}
+
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("err = %T(%v), want *APIError", err, err)
+	}
+	if apiErr.Code != 403 {
+		t.Errorf("Code = %d, want 403", apiErr.Code)
+	}
+	if apiErr.Status != "RESOURCE_EXHAUSTED" {
+		t.Errorf("Status = %q, want %q", apiErr.Status, "RESOURCE_EXHAUSTED")
+	}
+}
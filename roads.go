@@ -18,14 +18,30 @@
 package maps
 
 import (
-	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"golang.org/x/net/context"
 )
 
+// maxSnapToRoadPoints is the maximum number of points accepted by the
+// snapToRoads endpoint in a single request.
+const maxSnapToRoadPoints = 100
+
+// maxNearestRoadsPoints is the maximum number of points accepted by the
+// nearestRoads endpoint in a single request.
+const maxNearestRoadsPoints = 100
+
+// maxSpeedLimitsPoints is the maximum number of path points accepted by
+// the speedLimits endpoint in a single request.
+const maxSpeedLimitsPoints = 100
+
+// maxSpeedLimitsPlaceIDs is the maximum number of placeIds accepted by
+// the speedLimits endpoint in a single request.
+const maxSpeedLimitsPlaceIDs = 100
+
 type snapToRoadResponse struct {
 	response *SnapToRoadResponse
 	err      error
@@ -37,11 +53,21 @@ func (c *Client) SnapToRoad(ctx context.Context, r *SnapToRoadRequest) (*SnapToR
 	if len(r.Path) == 0 {
 		return nil, errors.New("snapToRoad: You must specify a Path")
 	}
+	if len(r.Path) > maxSnapToRoadPoints {
+		if r.AutoChunk {
+			return c.snapToRoadChunked(ctx, r)
+		}
+		return nil, fmt.Errorf("snapToRoad: Path exceeds the maximum of %d points", maxSnapToRoadPoints)
+	}
+
+	if c.roadsClient != nil {
+		return c.snapToRoadGRPC(ctx, r)
+	}
 
 	chResult := make(chan snapToRoadResponse)
 
 	go func() {
-		resp, err := c.doGetSnapToRoad(r)
+		resp, err := c.doGetSnapToRoad(ctx, r)
 		chResult <- snapToRoadResponse{resp, err}
 	}()
 
@@ -53,7 +79,7 @@ func (c *Client) SnapToRoad(ctx context.Context, r *SnapToRoadRequest) (*SnapToR
 	}
 }
 
-func (c *Client) doGetSnapToRoad(r *SnapToRoadRequest) (*SnapToRoadResponse, error) {
+func (c *Client) doGetSnapToRoad(ctx context.Context, r *SnapToRoadRequest) (*SnapToRoadResponse, error) {
 	baseURL := "https://roads.googleapis.com/"
 	if c.baseURL != "" {
 		baseURL = c.baseURL
@@ -63,6 +89,7 @@ func (c *Client) doGetSnapToRoad(r *SnapToRoadRequest) (*SnapToRoadResponse, err
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	var p []string
 	for _, e := range r.Path {
@@ -73,6 +100,12 @@ func (c *Client) doGetSnapToRoad(r *SnapToRoadRequest) (*SnapToRoadResponse, err
 	if r.Interpolate {
 		q.Set("interpolate", "true")
 	}
+	if r.TravelMode != "" {
+		q.Set("travelMode", string(r.TravelMode))
+	}
+	if r.AssetID != "" {
+		q.Set("assetId", r.AssetID)
+	}
 	query, err := c.generateAuthQuery(req.URL.Path, q, false)
 	if err != nil {
 		return nil, err
@@ -86,10 +119,23 @@ func (c *Client) doGetSnapToRoad(r *SnapToRoadRequest) (*SnapToRoadResponse, err
 	defer resp.Body.Close()
 
 	response := &SnapToRoadResponse{}
-	err = json.NewDecoder(resp.Body).Decode(response)
+	err = c.decode(req, resp, response)
 	return response, err
 }
 
+// TravelMode constrains road snapping to roads appropriate for a particular
+// mode of travel.
+type TravelMode string
+
+const (
+	// TravelModeDriving constrains snapping to roads suitable for driving.
+	TravelModeDriving TravelMode = "driving"
+	// TravelModeCycling constrains snapping to roads suitable for cycling.
+	TravelModeCycling TravelMode = "cycling"
+	// TravelModeWalking constrains snapping to roads suitable for walking.
+	TravelModeWalking TravelMode = "walking"
+)
+
 // SnapToRoadRequest is the request structure for the Roads Snap to Road API.
 type SnapToRoadRequest struct {
 	// Path is the path to be snapped.
@@ -97,6 +143,17 @@ type SnapToRoadRequest struct {
 
 	// Interpolate is whether to interpolate a path to include all points forming the full road-geometry.
 	Interpolate bool
+
+	// TravelMode constrains snapping to roads appropriate for `TravelModeDriving`, `TravelModeCycling` or `TravelModeWalking`. Optional, default behavior is TravelModeDriving.
+	TravelMode TravelMode
+
+	// AssetID identifies the asset using an asset-based Roads API billing SKU. Optional.
+	AssetID string
+
+	// AutoChunk splits a Path longer than the API's per-request point limit
+	// into sequential sub-requests, issues them concurrently and stitches
+	// the results back together in original order. Optional.
+	AutoChunk bool
 }
 
 // SnapToRoadResponse is an array of snapped points.
@@ -116,6 +173,100 @@ type SnappedPoint struct {
 	PlaceID string `json:"placeId"`
 }
 
+type nearestRoadsResponse struct {
+	response *NearestRoadsResponse
+	err      error
+}
+
+// NearestRoads makes a NearestRoads API request. Unlike SnapToRoad, the
+// points passed are considered independent and are not assumed to be a
+// continuous path.
+func (c *Client) NearestRoads(ctx context.Context, r *NearestRoadsRequest) (*NearestRoadsResponse, error) {
+
+	if len(r.Points) == 0 {
+		return nil, errors.New("nearestRoads: You must specify Points")
+	}
+	if len(r.Points) > maxNearestRoadsPoints {
+		return nil, fmt.Errorf("nearestRoads: Points exceeds the maximum of %d points", maxNearestRoadsPoints)
+	}
+
+	if c.roadsClient != nil {
+		return c.nearestRoadsGRPC(ctx, r)
+	}
+
+	chResult := make(chan nearestRoadsResponse)
+
+	go func() {
+		resp, err := c.doGetNearestRoads(ctx, r)
+		chResult <- nearestRoadsResponse{resp, err}
+	}()
+
+	select {
+	case resp := <-chResult:
+		return resp.response, resp.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Client) doGetNearestRoads(ctx context.Context, r *NearestRoadsRequest) (*NearestRoadsResponse, error) {
+	baseURL := "https://roads.googleapis.com/"
+	if c.baseURL != "" {
+		baseURL = c.baseURL
+	}
+
+	req, err := http.NewRequest("GET", baseURL+"/v1/nearestRoads", nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	q := req.URL.Query()
+	var p []string
+	for _, e := range r.Points {
+		p = append(p, e.String())
+	}
+
+	q.Set("points", strings.Join(p, "|"))
+	if r.TravelMode != "" {
+		q.Set("travelMode", string(r.TravelMode))
+	}
+	if r.AssetID != "" {
+		q.Set("assetId", r.AssetID)
+	}
+	query, err := c.generateAuthQuery(req.URL.Path, q, false)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = query
+
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &NearestRoadsResponse{}
+	err = c.decode(req, resp, response)
+	return response, err
+}
+
+// NearestRoadsRequest is the request structure for the Roads Nearest Roads API.
+type NearestRoadsRequest struct {
+	// Points is the list of independent points to be snapped to the nearest road segments.
+	Points []LatLng
+
+	// TravelMode constrains snapping to roads appropriate for `TravelModeDriving`, `TravelModeCycling` or `TravelModeWalking`. Optional, default behavior is TravelModeDriving.
+	TravelMode TravelMode
+
+	// AssetID identifies the asset using an asset-based Roads API billing SKU. Optional.
+	AssetID string
+}
+
+// NearestRoadsResponse is an array of snapped points.
+type NearestRoadsResponse struct {
+	SnappedPoints []SnappedPoint `json:"snappedPoints"`
+}
+
 type speedLimitsResponse struct {
 	response *SpeedLimitsResponse
 	err      error
@@ -127,11 +278,18 @@ func (c *Client) SpeedLimits(ctx context.Context, r *SpeedLimitsRequest) (*Speed
 	if len(r.Path) == 0 && len(r.PlaceID) == 0 {
 		return nil, errors.New("speedLimits: You must specify a Path or PlaceID")
 	}
+	if r.AutoChunk && (len(r.Path) > maxSpeedLimitsPoints || len(r.PlaceID) > maxSpeedLimitsPlaceIDs) {
+		return c.speedLimitsChunked(ctx, r)
+	}
+
+	if c.roadsClient != nil {
+		return c.speedLimitsGRPC(ctx, r)
+	}
 
 	chResult := make(chan speedLimitsResponse)
 
 	go func() {
-		resp, err := c.doGetSpeedLimits(r)
+		resp, err := c.doGetSpeedLimits(ctx, r)
 		chResult <- speedLimitsResponse{resp, err}
 	}()
 
@@ -143,7 +301,7 @@ func (c *Client) SpeedLimits(ctx context.Context, r *SpeedLimitsRequest) (*Speed
 	}
 }
 
-func (c *Client) doGetSpeedLimits(r *SpeedLimitsRequest) (*SpeedLimitsResponse, error) {
+func (c *Client) doGetSpeedLimits(ctx context.Context, r *SpeedLimitsRequest) (*SpeedLimitsResponse, error) {
 
 	baseURL := "https://roads.googleapis.com/"
 	if c.baseURL != "" {
@@ -154,6 +312,7 @@ func (c *Client) doGetSpeedLimits(r *SpeedLimitsRequest) (*SpeedLimitsResponse,
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	var p []string
 	for _, e := range r.Path {
@@ -182,7 +341,7 @@ func (c *Client) doGetSpeedLimits(r *SpeedLimitsRequest) (*SpeedLimitsResponse,
 	defer resp.Body.Close()
 
 	response := &SpeedLimitsResponse{}
-	err = json.NewDecoder(resp.Body).Decode(response)
+	err = c.decode(req, resp, response)
 	return response, err
 }
 
@@ -205,6 +364,11 @@ type SpeedLimitsRequest struct {
 
 	// Units is whether to return speed limits in `SpeedLimitKPH` or `SpeedLimitMPH`. Optional, default behavior is to return results in KPH.
 	Units speedLimitUnit
+
+	// AutoChunk splits a Path or PlaceID list longer than the API's
+	// per-request limit into sequential sub-requests, issues them
+	// concurrently and stitches the results back together. Optional.
+	AutoChunk bool
 }
 
 // SpeedLimitsResponse is an array of snapped points and an array of speed limits.
@@ -0,0 +1,221 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// chunkWorkers bounds how many chunk sub-requests are in flight at once
+// when AutoChunk splits a request.
+const chunkWorkers = 4
+
+// chunkOverlap is the number of points each chunk after the first repeats
+// from the end of the previous chunk, so that Interpolate can produce a
+// continuous road-geometry across the seam.
+const chunkOverlap = 3
+
+// pathChunk is a sub-range of a larger path, along with the index at
+// which it begins in the original, unchunked path.
+type pathChunk struct {
+	points []LatLng
+	start  int
+}
+
+// chunkPath splits path into sub-ranges of at most size points. When
+// overlap is non-zero, each chunk after the first repeats the last
+// overlap points of the previous chunk.
+func chunkPath(path []LatLng, size, overlap int) []pathChunk {
+	var chunks []pathChunk
+	start := 0
+	for start < len(path) {
+		end := start + size
+		if end > len(path) {
+			end = len(path)
+		}
+		chunks = append(chunks, pathChunk{points: path[start:end], start: start})
+		if end == len(path) {
+			break
+		}
+		next := end - overlap
+		if next <= start {
+			next = end
+		}
+		start = next
+	}
+	return chunks
+}
+
+// stitchSnappedPoints merges the SnappedPoints of sequential, possibly
+// overlapping chunks back into a single, ordered slice: OriginalIndex is
+// rebased onto the original path, and a chunk's points up to and
+// including its last duplicate of the previous chunk's tail are dropped.
+// Everything after that last duplicate is kept as-is, including any
+// interpolated (nil OriginalIndex) points immediately following it, so
+// the interpolated geometry stays continuous across the seam.
+func stitchSnappedPoints(chunks []pathChunk, responses []*SnapToRoadResponse) []SnappedPoint {
+	var out []SnappedPoint
+	lastIndex := -1
+	for i, resp := range responses {
+		start := chunks[i].start
+		points := resp.SnappedPoints
+
+		cut := -1
+		if i > 0 {
+			for j, sp := range points {
+				if sp.OriginalIndex != nil && *sp.OriginalIndex+start <= lastIndex {
+					cut = j
+				}
+			}
+		}
+
+		for _, sp := range points[cut+1:] {
+			if sp.OriginalIndex != nil {
+				rebased := *sp.OriginalIndex + start
+				idx := rebased
+				sp.OriginalIndex = &idx
+				lastIndex = rebased
+			}
+			out = append(out, sp)
+		}
+	}
+	return out
+}
+
+// snapToRoadChunked implements SnapToRoad for a Path larger than
+// maxSnapToRoadPoints by splitting it into overlapping chunks, snapping
+// each concurrently, and stitching the results back together in order.
+func (c *Client) snapToRoadChunked(ctx context.Context, r *SnapToRoadRequest) (*SnapToRoadResponse, error) {
+	overlap := 0
+	if r.Interpolate {
+		overlap = chunkOverlap
+	}
+	chunks := chunkPath(r.Path, maxSnapToRoadPoints, overlap)
+
+	responses := make([]*SnapToRoadResponse, len(chunks))
+	errs := make([]error, len(chunks))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, chunkWorkers)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk pathChunk) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			sub := &SnapToRoadRequest{
+				Path:        chunk.points,
+				Interpolate: r.Interpolate,
+				TravelMode:  r.TravelMode,
+				AssetID:     r.AssetID,
+			}
+			responses[i], errs[i] = c.SnapToRoad(ctx, sub)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &SnapToRoadResponse{SnappedPoints: stitchSnappedPoints(chunks, responses)}, nil
+}
+
+// dedupeStrings returns ss with later duplicates of an already-seen
+// value removed, preserving the order of first occurrence.
+func dedupeStrings(ss []string) []string {
+	seen := make(map[string]bool, len(ss))
+	out := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	return out
+}
+
+// chunkStrings splits ss into sub-slices of at most size elements each.
+func chunkStrings(ss []string, size int) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(ss); start += size {
+		end := start + size
+		if end > len(ss) {
+			end = len(ss)
+		}
+		chunks = append(chunks, ss[start:end])
+	}
+	return chunks
+}
+
+// speedLimitsChunked implements SpeedLimits for a Path or PlaceID list
+// larger than the API's per-request limits. The speedLimits endpoint
+// treats path and placeId as mutually exclusive, so a Path chunk and a
+// PlaceID chunk are never combined into the same sub-request: each is
+// issued as its own SpeedLimitsRequest, concurrently, and the results
+// are merged back together.
+func (c *Client) speedLimitsChunked(ctx context.Context, r *SpeedLimitsRequest) (*SpeedLimitsResponse, error) {
+	var pathChunks []pathChunk
+	if len(r.Path) > 0 {
+		pathChunks = chunkPath(r.Path, maxSpeedLimitsPoints, 0)
+	}
+	placeIDChunks := chunkStrings(dedupeStrings(r.PlaceID), maxSpeedLimitsPlaceIDs)
+
+	responses := make([]*SpeedLimitsResponse, len(pathChunks)+len(placeIDChunks))
+	errs := make([]error, len(responses))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, chunkWorkers)
+	query := func(i int, sub *SpeedLimitsRequest) {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, sub *SpeedLimitsRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			responses[i], errs[i] = c.SpeedLimits(ctx, sub)
+		}(i, sub)
+	}
+	for i, chunk := range pathChunks {
+		query(i, &SpeedLimitsRequest{Path: chunk.points, Units: r.Units})
+	}
+	for i, ids := range placeIDChunks {
+		query(len(pathChunks)+i, &SpeedLimitsRequest{PlaceID: ids, Units: r.Units})
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := &SpeedLimitsResponse{}
+	for _, resp := range responses {
+		out.SpeedLimits = append(out.SpeedLimits, resp.SpeedLimits...)
+	}
+	if len(pathChunks) > 0 {
+		pathResponses := make([]*SnapToRoadResponse, len(pathChunks))
+		for i := range pathChunks {
+			pathResponses[i] = &SnapToRoadResponse{SnappedPoints: responses[i].SnappedPoints}
+		}
+		out.SnappedPoints = stitchSnappedPoints(pathChunks, pathResponses)
+	}
+	return out, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestChunkPath(t *testing.T) {
+	path := make([]LatLng, 10)
+	for i := range path {
+		path[i] = LatLng{Lat: float64(i), Lng: float64(i)}
+	}
+
+	tests := []struct {
+		name    string
+		size    int
+		overlap int
+		starts  []int
+		lens    []int
+	}{
+		{name: "no overlap, exact chunks", size: 5, overlap: 0, starts: []int{0, 5}, lens: []int{5, 5}},
+		{name: "no overlap, ragged last chunk", size: 4, overlap: 0, starts: []int{0, 4, 8}, lens: []int{4, 4, 2}},
+		{name: "overlap repeats tail points", size: 4, overlap: 1, starts: []int{0, 3, 6}, lens: []int{4, 4, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := chunkPath(path, tt.size, tt.overlap)
+			if len(chunks) != len(tt.starts) {
+				t.Fatalf("got %d chunks, want %d", len(chunks), len(tt.starts))
+			}
+			for i, chunk := range chunks {
+				if chunk.start != tt.starts[i] {
+					t.Errorf("chunk %d: start = %d, want %d", i, chunk.start, tt.starts[i])
+				}
+				if len(chunk.points) != tt.lens[i] {
+					t.Errorf("chunk %d: len(points) = %d, want %d", i, len(chunk.points), tt.lens[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStitchSnappedPoints_RebasesOriginalIndex(t *testing.T) {
+	// chunk 0 covers original indexes 0-4; chunk 1 starts at 3, so its
+	// local indexes 0 and 1 (rebased 3 and 4) duplicate chunk 0's tail
+	// and only its local index 2 (rebased 5) is genuinely new.
+	chunks := []pathChunk{{start: 0}, {start: 3}}
+	responses := []*SnapToRoadResponse{
+		{SnappedPoints: []SnappedPoint{
+			{OriginalIndex: intPtr(0)},
+			{OriginalIndex: intPtr(1)},
+			{OriginalIndex: intPtr(2)},
+			{OriginalIndex: intPtr(3)},
+			{OriginalIndex: intPtr(4)},
+		}},
+		{SnappedPoints: []SnappedPoint{
+			{OriginalIndex: intPtr(0)}, // duplicate, rebases to 3
+			{OriginalIndex: intPtr(1)}, // duplicate, rebases to 4
+			{OriginalIndex: intPtr(2)}, // new, rebases to 5
+		}},
+	}
+
+	got := stitchSnappedPoints(chunks, responses)
+	want := []int{0, 1, 2, 3, 4, 5}
+	if len(got) != len(want) {
+		t.Fatalf("got %d points, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].OriginalIndex == nil || *got[i].OriginalIndex != w {
+			t.Errorf("point %d: OriginalIndex = %v, want %d", i, got[i].OriginalIndex, w)
+		}
+	}
+}
+
+// TestStitchSnappedPoints_ContinuousInterpolationAcrossSeam asserts that
+// interpolated points (nil OriginalIndex) immediately following the
+// duplicated seam point are kept, not dropped, so Interpolate=true stays
+// continuous across a chunk boundary.
+func TestStitchSnappedPoints_ContinuousInterpolationAcrossSeam(t *testing.T) {
+	chunks := []pathChunk{{start: 0}, {start: 2}}
+	responses := []*SnapToRoadResponse{
+		{SnappedPoints: []SnappedPoint{
+			{OriginalIndex: intPtr(0)},
+			{OriginalIndex: nil}, // interpolated, between original 0 and 2
+			{OriginalIndex: intPtr(2)},
+		}},
+		{SnappedPoints: []SnappedPoint{
+			{OriginalIndex: intPtr(0)}, // duplicate of chunk 0's index 2
+			{OriginalIndex: nil},       // interpolated, between original 2 and 3 -- must survive
+			{OriginalIndex: intPtr(1)}, // original index 3
+		}},
+	}
+
+	got := stitchSnappedPoints(chunks, responses)
+
+	var gotIndexes []interface{}
+	for _, sp := range got {
+		if sp.OriginalIndex == nil {
+			gotIndexes = append(gotIndexes, nil)
+		} else {
+			gotIndexes = append(gotIndexes, *sp.OriginalIndex)
+		}
+	}
+	want := []interface{}{0, nil, 2, nil, 3}
+	if !reflect.DeepEqual(gotIndexes, want) {
+		t.Errorf("stitched OriginalIndex sequence = %v, want %v (seam interpolation must not be dropped)", gotIndexes, want)
+	}
+}
+
+func TestSpeedLimitsChunked_SplitsPathAndPlaceIDIntoSeparateRequests(t *testing.T) {
+	var mu []string
+	var pathCalls, placeIDCalls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		path := q.Get("path")
+		placeIDs := q["placeId"]
+		if path != "" && len(placeIDs) > 0 {
+			t.Errorf("request combined path and placeId in one call: path=%q placeId=%v", path, placeIDs)
+		}
+		if path != "" {
+			pathCalls++
+		}
+		if len(placeIDs) > 0 {
+			placeIDCalls++
+			mu = append(mu, placeIDs...)
+		}
+		fmt.Fprint(w, `{"speedLimits":[],"snappedPoints":[]}`)
+	}))
+	defer ts.Close()
+
+	c := &Client{httpClient: ts.Client(), baseURL: ts.URL}
+
+	path := make([]LatLng, maxSpeedLimitsPoints+1)
+	placeIDs := make([]string, maxSpeedLimitsPlaceIDs+1)
+	for i := range placeIDs {
+		placeIDs[i] = fmt.Sprintf("place-%d", i)
+	}
+	placeIDs = append(placeIDs, placeIDs[0]) // duplicate, should be deduped away
+
+	_, err := c.SpeedLimits(context.Background(), &SpeedLimitsRequest{
+		Path:      path,
+		PlaceID:   placeIDs,
+		AutoChunk: true,
+	})
+	if err != nil {
+		t.Fatalf("SpeedLimits returned error: %v", err)
+	}
+
+	if pathCalls != 2 {
+		t.Errorf("path issued as %d requests, want 2 (chunked)", pathCalls)
+	}
+	if placeIDCalls != 2 {
+		t.Errorf("placeIDs issued as %d requests, want 2 (chunked)", placeIDCalls)
+	}
+	seen := make(map[string]bool)
+	for _, id := range mu {
+		if seen[id] {
+			t.Errorf("placeId %q sent more than once across chunks", id)
+		}
+		seen[id] = true
+	}
+}
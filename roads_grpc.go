@@ -0,0 +1,163 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	latlngpb "google.golang.org/genproto/googleapis/type/latlng"
+
+	roadspb "github.com/julianshen/google-maps-services-go/roads/v1op"
+)
+
+// WithGRPC returns a ClientOption that routes SnapToRoad, NearestRoads and
+// SpeedLimits through the Roads API's gRPC service using conn, instead of
+// building REST requests against roads.googleapis.com. This gives callers
+// HTTP/2 multiplexing, context-based streaming deadlines, and typed
+// google.golang.org/grpc/status errors in place of hand-parsed JSON.
+func WithGRPC(conn *grpc.ClientConn) ClientOption {
+	return func(c *Client) error {
+		c.roadsClient = roadspb.NewRoadsServiceClient(conn)
+		return nil
+	}
+}
+
+func travelModeToProto(t TravelMode) roadspb.TravelMode {
+	switch t {
+	case TravelModeCycling:
+		return roadspb.TravelMode_CYCLING
+	case TravelModeWalking:
+		return roadspb.TravelMode_WALKING
+	default:
+		return roadspb.TravelMode_DRIVING
+	}
+}
+
+func latLngToProto(l LatLng) *latlngpb.LatLng {
+	return &latlngpb.LatLng{Latitude: l.Lat, Longitude: l.Lng}
+}
+
+func latLngFromProto(l *latlngpb.LatLng) LatLng {
+	return LatLng{Lat: l.GetLatitude(), Lng: l.GetLongitude()}
+}
+
+func snappedPointFromProto(sp *roadspb.SnappedPoint) SnappedPoint {
+	out := SnappedPoint{
+		Location: latLngFromProto(sp.GetLocation()),
+		PlaceID:  sp.GetPlaceId(),
+	}
+	if sp.OriginalIndex != nil {
+		idx := int(sp.GetOriginalIndex().GetValue())
+		out.OriginalIndex = &idx
+	}
+	return out
+}
+
+func (c *Client) snapToRoadGRPC(ctx context.Context, r *SnapToRoadRequest) (*SnapToRoadResponse, error) {
+	req := &roadspb.SnapToRoadsRequest{
+		Path:        make([]*latlngpb.LatLng, len(r.Path)),
+		Interpolate: r.Interpolate,
+		TravelMode:  travelModeToProto(r.TravelMode),
+		AssetId:     r.AssetID,
+	}
+	for i, p := range r.Path {
+		req.Path[i] = latLngToProto(p)
+	}
+
+	resp, err := c.roadsClient.SnapToRoads(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SnapToRoadResponse{SnappedPoints: make([]SnappedPoint, len(resp.GetSnappedPoints()))}
+	for i, sp := range resp.GetSnappedPoints() {
+		out.SnappedPoints[i] = snappedPointFromProto(sp)
+	}
+	return out, nil
+}
+
+func (c *Client) nearestRoadsGRPC(ctx context.Context, r *NearestRoadsRequest) (*NearestRoadsResponse, error) {
+	req := &roadspb.ListNearestRoadsRequest{
+		Points:     make([]*latlngpb.LatLng, len(r.Points)),
+		TravelMode: travelModeToProto(r.TravelMode),
+		AssetId:    r.AssetID,
+	}
+	for i, p := range r.Points {
+		req.Points[i] = latLngToProto(p)
+	}
+
+	resp, err := c.roadsClient.ListNearestRoads(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &NearestRoadsResponse{SnappedPoints: make([]SnappedPoint, len(resp.GetSnappedPoints()))}
+	for i, sp := range resp.GetSnappedPoints() {
+		out.SnappedPoints[i] = snappedPointFromProto(sp)
+	}
+	return out, nil
+}
+
+// speedUnitToProto maps our public speedLimitUnit constants onto the
+// generated SpeedUnit enum explicitly, rather than relying on the enum's
+// generated member names matching our JSON values.
+func speedUnitToProto(u speedLimitUnit) roadspb.SpeedUnit {
+	if u == SpeedLimitMPH {
+		return roadspb.SpeedUnit_SPEED_UNIT_MPH
+	}
+	return roadspb.SpeedUnit_SPEED_UNIT_KPH
+}
+
+// speedUnitFromProto is the inverse of speedUnitToProto, so gRPC and REST
+// calls report identical Units values for the same request.
+func speedUnitFromProto(u roadspb.SpeedUnit) speedLimitUnit {
+	if u == roadspb.SpeedUnit_SPEED_UNIT_MPH {
+		return SpeedLimitMPH
+	}
+	return SpeedLimitKPH
+}
+
+func (c *Client) speedLimitsGRPC(ctx context.Context, r *SpeedLimitsRequest) (*SpeedLimitsResponse, error) {
+	req := &roadspb.GetSpeedLimitsRequest{
+		PlaceIds: r.PlaceID,
+		Path:     make([]*latlngpb.LatLng, len(r.Path)),
+		Units:    speedUnitToProto(r.Units),
+	}
+	for i, p := range r.Path {
+		req.Path[i] = latLngToProto(p)
+	}
+
+	resp, err := c.roadsClient.GetSpeedLimits(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SpeedLimitsResponse{
+		SpeedLimits:   make([]SpeedLimit, len(resp.GetSpeedLimits())),
+		SnappedPoints: make([]SnappedPoint, len(resp.GetSnappedPoints())),
+	}
+	for i, sl := range resp.GetSpeedLimits() {
+		out.SpeedLimits[i] = SpeedLimit{
+			PlaceID:    sl.GetPlaceId(),
+			SpeedLimit: float64(sl.GetSpeedLimit()),
+			Units:      speedUnitFromProto(sl.GetUnits()),
+		}
+	}
+	for i, sp := range resp.GetSnappedPoints() {
+		out.SnappedPoints[i] = snappedPointFromProto(sp)
+	}
+	return out, nil
+}
@@ -0,0 +1,29 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+import "testing"
+
+// TestSpeedUnitRoundTrip asserts that SpeedLimitsRequest.Units survives a
+// round trip through the gRPC proto enum unchanged, so that REST and
+// gRPC transports report the same Units for the same request.
+func TestSpeedUnitRoundTrip(t *testing.T) {
+	for _, unit := range []speedLimitUnit{SpeedLimitKPH, SpeedLimitMPH} {
+		got := speedUnitFromProto(speedUnitToProto(unit))
+		if got != unit {
+			t.Errorf("speedUnitFromProto(speedUnitToProto(%v)) = %v, want %v", unit, got, unit)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestNearestRoads_QueryBuilding(t *testing.T) {
+	var gotPath, gotQuery string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotPath = req.URL.Path
+		gotQuery = req.URL.Query().Get("points")
+		if mode := req.URL.Query().Get("travelMode"); mode != "driving" {
+			t.Errorf("travelMode = %q, want %q", mode, "driving")
+		}
+		if asset := req.URL.Query().Get("assetId"); asset != "my-asset" {
+			t.Errorf("assetId = %q, want %q", asset, "my-asset")
+		}
+		fmt.Fprint(w, `{"snappedPoints":[{"location":{"lat":1,"lng":2},"placeId":"p1"}]}`)
+	}))
+	defer ts.Close()
+
+	c := &Client{httpClient: ts.Client(), baseURL: ts.URL}
+
+	resp, err := c.NearestRoads(context.Background(), &NearestRoadsRequest{
+		Points:     []LatLng{{Lat: 1, Lng: 2}, {Lat: 3, Lng: 4}},
+		TravelMode: TravelModeDriving,
+		AssetID:    "my-asset",
+	})
+	if err != nil {
+		t.Fatalf("NearestRoads returned error: %v", err)
+	}
+
+	if gotPath != "/v1/nearestRoads" {
+		t.Errorf("request path = %q, want %q", gotPath, "/v1/nearestRoads")
+	}
+	wantPoints := "1,2|3,4"
+	if gotQuery != wantPoints {
+		t.Errorf("points query = %q, want %q", gotQuery, wantPoints)
+	}
+	if len(resp.SnappedPoints) != 1 || resp.SnappedPoints[0].PlaceID != "p1" {
+		t.Errorf("SnappedPoints = %+v, want a single point with PlaceID p1", resp.SnappedPoints)
+	}
+}
+
+func TestNearestRoads_RejectsEmptyPoints(t *testing.T) {
+	c := &Client{httpClient: http.DefaultClient}
+	if _, err := c.NearestRoads(context.Background(), &NearestRoadsRequest{}); err == nil {
+		t.Error("NearestRoads with no Points: got nil error, want one")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// defaultRetryMaxTries is the default number of attempts made by a
+// retryTransport before giving up.
+const defaultRetryMaxTries = 5
+
+// retryBaseWait is the wait before the first retry. Each subsequent retry
+// doubles the previous wait.
+const retryBaseWait = 1 * time.Second
+
+// retryJitter is the maximum jitter, in either direction, added to each
+// wait so that concurrent clients don't retry in lockstep.
+const retryJitter = 500 * time.Millisecond
+
+// HTTPError is returned when a request ultimately fails with a 5xx
+// status code after the retry policy has been exhausted. Responses with
+// other status codes, including 4xx, are returned as-is without an error.
+type HTTPError struct {
+	// Response is the last HTTP response received for the request.
+	Response *http.Response
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("maps: HTTP request failed with status %s", e.Response.Status)
+}
+
+// retryTransport is an http.RoundTripper that retries requests that fail
+// with a network error or a 5xx status code, using exponential backoff
+// with jitter between attempts.
+type retryTransport struct {
+	// Base is the underlying RoundTripper used to make requests. Defaults
+	// to http.DefaultTransport.
+	Base http.RoundTripper
+
+	// MaxTries is the maximum number of attempts made for a single
+	// request, including the first. Defaults to defaultRetryMaxTries.
+	MaxTries int
+
+	// Sleep is used to wait between retries. Defaults to a function that
+	// sleeps for the given duration; overridden in tests with a fake
+	// sleeper so backoff can be exercised without slowing down the suite.
+	Sleep func(d time.Duration)
+}
+
+func (t *retryTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *retryTransport) maxTries() int {
+	if t.MaxTries > 0 {
+		return t.MaxTries
+	}
+	return defaultRetryMaxTries
+}
+
+// wait pauses for d between retries. If t.Sleep is set (as tests do, to
+// exercise backoff without slowing down the suite), it is used verbatim;
+// otherwise the wait honours req's context so cancellation stays prompt.
+func (t *retryTransport) wait(req *http.Request, d time.Duration) error {
+	if t.Sleep != nil {
+		t.Sleep(d)
+		return nil
+	}
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	wait := retryBaseWait
+	var resp *http.Response
+	var err error
+
+	for try := 0; try < t.maxTries(); try++ {
+		if try > 0 {
+			jitter := time.Duration(rand.Int63n(int64(2*retryJitter))) - retryJitter
+			if waitErr := t.wait(req, wait+jitter); waitErr != nil {
+				return nil, waitErr
+			}
+			wait *= 2
+		}
+
+		resp, err = t.base().RoundTrip(req)
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+
+		if err == nil && resp.StatusCode >= http.StatusInternalServerError {
+			// This response won't be returned to the caller: drain and
+			// close it so the underlying connection can be reused by the
+			// pool. A RoundTripper must not return a non-nil response
+			// alongside a non-nil error, so on the final attempt the
+			// body is closed and only the error is returned.
+			httpErr := &HTTPError{Response: resp}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+			if try == t.maxTries()-1 {
+				return nil, httpErr
+			}
+			continue
+		}
+	}
+	return resp, err
+}
+
+// WithRetryPolicy returns a ClientOption that makes the Client retry
+// requests that fail with a network error or a 5xx status, up to maxTries
+// attempts, using exponential backoff with jitter starting at one second.
+func WithRetryPolicy(maxTries int) ClientOption {
+	return func(c *Client) error {
+		c.httpClient.Transport = &retryTransport{
+			Base:     c.httpClient.Transport,
+			MaxTries: maxTries,
+		}
+		return nil
+	}
+}
+
+// WithRetryTransport returns a ClientOption that routes all requests
+// through rt. Options are applied in order: passing WithRetryTransport
+// before WithRetryPolicy makes rt the base transport that the retry
+// policy wraps, while passing it after replaces the retry policy.
+func WithRetryTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		c.httpClient.Transport = rt
+		return nil
+	}
+}
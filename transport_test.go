@@ -0,0 +1,101 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingRoundTripper returns 500 for the first failCount requests, then
+// 200.
+type countingRoundTripper struct {
+	failCount int
+	calls     int
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.calls++
+	status := http.StatusOK
+	if rt.calls <= rt.failCount {
+		status = http.StatusInternalServerError
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestRetryTransport_RetriesThenSucceeds(t *testing.T) {
+	base := &countingRoundTripper{failCount: 2}
+	var slept []time.Duration
+	rt := &retryTransport{
+		Base:     base,
+		MaxTries: 5,
+		Sleep:    func(d time.Duration) { slept = append(slept, d) },
+	}
+
+	req := httptest.NewRequest("GET", "http://example.com", nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+	if base.calls != 3 {
+		t.Errorf("base RoundTripper called %d times, want 3 (2 failures + 1 success)", base.calls)
+	}
+	if len(slept) != 2 {
+		t.Fatalf("slept %d times, want 2", len(slept))
+	}
+	// Second wait should be roughly double the first (exponential
+	// backoff), allowing for the +/-500ms jitter on each.
+	if slept[1] < slept[0] {
+		t.Errorf("wait did not grow between retries: %v then %v", slept[0], slept[1])
+	}
+}
+
+func TestRetryTransport_ExhaustsRetriesAndReturnsHTTPError(t *testing.T) {
+	base := &countingRoundTripper{failCount: 100}
+	rt := &retryTransport{
+		Base:     base,
+		MaxTries: 3,
+		Sleep:    func(time.Duration) {},
+	}
+
+	req := httptest.NewRequest("GET", "http://example.com", nil)
+	resp, err := rt.RoundTrip(req)
+
+	if resp != nil {
+		t.Errorf("resp = %v, want nil alongside a non-nil error", resp)
+	}
+	httpErr, ok := err.(*HTTPError)
+	if !ok {
+		t.Fatalf("err = %T(%v), want *HTTPError", err, err)
+	}
+	if httpErr.Response.StatusCode != http.StatusInternalServerError {
+		t.Errorf("HTTPError.Response.StatusCode = %d, want 500", httpErr.Response.StatusCode)
+	}
+	if base.calls != 3 {
+		t.Errorf("base RoundTripper called %d times, want MaxTries=3", base.calls)
+	}
+}